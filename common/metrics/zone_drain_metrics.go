@@ -0,0 +1,11 @@
+package metrics
+
+// TasksRejectedDrainedZoneCounter counts AddTask calls failed outright because their task list's local
+// zone is drained and zone forwarding is disabled.
+//
+// TasksForwardedDrainedZoneCounter counts AddTask calls forwarded to another zone because their task
+// list's local zone is drained and zone forwarding is enabled.
+const (
+	TasksRejectedDrainedZoneCounter = iota
+	TasksForwardedDrainedZoneCounter
+)
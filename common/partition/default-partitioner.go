@@ -3,7 +3,9 @@ package partition
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+
 	"github.com/dgryski/go-farm"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/dynamicconfig"
@@ -14,8 +16,9 @@ import (
 )
 
 type DefaultPartitionConfig struct {
-	WorkflowStartZone types.ZoneName `json:"wf-start-zone"`
-	RunID             string         `json:"run-id"`
+	WorkflowStartZone types.ZoneName    `json:"wf-start-zone"`
+	RunID             string            `json:"run-id"`
+	Attributes        map[string]string `json:"attributes,omitempty"`
 }
 
 type DefaultPartitioner struct {
@@ -26,23 +29,29 @@ type DefaultPartitioner struct {
 }
 
 type DefaultZoneStateHandler struct {
-	domainCache      cache.DomainCache
-	globalZoneDrains persistence.GlobalZoneDrains
-	allZonesList     []types.ZoneName
-	log              log.Logger
-	config           Config
-	mu               sync.RWMutex
+	domainCache        cache.DomainCache
+	globalZoneDrains   persistence.GlobalZoneDrains
+	domainZonePolicies persistence.DomainConfigStore
+	allZonesList       []types.ZoneName
+	log                log.Logger
+	config             Config
+	mu                 sync.RWMutex
 }
 
 type Config struct {
 	zonalPartitioningEnabled dynamicconfig.BoolPropertyFnWithDomainFilter
+	// activeStrategy selects, per-domain, which Registry-registered Strategy name should handle
+	// partitioning. An empty value (the zero value of the property, or the property itself being unset)
+	// falls back to the "default" strategy.
+	activeStrategy dynamicconfig.StringPropertyFnWithDomainFilter
 }
 
-func NewDefaultZoneStateWatcher(logger log.Logger, allZones []types.ZoneName, config Config) ZoneState {
+func NewDefaultZoneStateWatcher(logger log.Logger, allZones []types.ZoneName, config Config, domainZonePolicies persistence.DomainConfigStore) ZoneState {
 	return &DefaultZoneStateHandler{
-		log:          logger,
-		allZonesList: allZones,
-		config:       config,
+		log:                logger,
+		allZonesList:       allZones,
+		config:             config,
+		domainZonePolicies: domainZonePolicies,
 	}
 }
 
@@ -52,15 +61,29 @@ func NewDefaultTaskResolver(logger log.Logger) Partitioner {
 	}
 }
 
+// IsDrained reports whether zone should be avoided for new placements in domain. A zone that is still
+// Preparing a drain is treated as drained here, even though in-flight assignments are left alone until
+// the drain commits - see DefaultZoneStateHandler.Get.
 func (r *DefaultPartitioner) IsDrained(ctx context.Context, domain string, zone types.ZoneName) (bool, error) {
 	state, err := r.drainState.Get(ctx, domain, zone)
 	if err != nil {
 		return false, fmt.Errorf("could not determine if drained: %w", err)
 	}
-	return state.Status == types.ZoneDrainStatusDrained, nil
+	return isDrainingOrDrained(state.Status), nil
 }
 
 func (r *DefaultPartitioner) IsDrainedByDomainID(ctx context.Context, domainID string, zone types.ZoneName) (bool, error) {
+	state, err := r.drainState.GetByDomainID(ctx, domainID, zone)
+	if err != nil {
+		return false, fmt.Errorf("could not determine if drained: %w", err)
+	}
+	return isDrainingOrDrained(state.Status), nil
+}
+
+// IsFullyDrainedByDomainID reports whether zone has completed its drain for domainID, unlike
+// IsDrainedByDomainID a zone that is only Preparing returns false here - so in-flight work already
+// assigned to zone keeps flowing right up until the drain commits.
+func (r *DefaultPartitioner) IsFullyDrainedByDomainID(ctx context.Context, domainID string, zone types.ZoneName) (bool, error) {
 	state, err := r.drainState.GetByDomainID(ctx, domainID, zone)
 	if err != nil {
 		return false, fmt.Errorf("could not determine if drained: %w", err)
@@ -68,6 +91,32 @@ func (r *DefaultPartitioner) IsDrainedByDomainID(ctx context.Context, domainID s
 	return state.Status == types.ZoneDrainStatusDrained, nil
 }
 
+// isDrainingOrDrained treats a zone that is Preparing a drain the same as one that has already Drained,
+// for the purposes of deciding whether new placements should avoid it.
+func isDrainingOrDrained(status types.ZoneStatus) bool {
+	return status == types.ZoneDrainStatusDrained || status == types.ZoneDrainStatusPreparing
+}
+
+// Validate reports whether key is well-formed for DefaultPartitioner: it must decode as a
+// DefaultPartitionConfig with a non-empty workflow start zone.
+func (r *DefaultPartitioner) Validate(key types.PartitionConfig) error {
+	var cfg DefaultPartitionConfig
+	if err := json.Unmarshal(key, &cfg); err != nil {
+		return fmt.Errorf("invalid partition config for %q strategy: %w", defaultStrategyName, err)
+	}
+	if cfg.WorkflowStartZone == "" {
+		return fmt.Errorf("partition config for %q strategy must set \"wf-start-zone\"", defaultStrategyName)
+	}
+	return nil
+}
+
+// ValidateStartWorkflowPartitionConfig is Validate, satisfying Partitioner's start-workflow validation
+// entry point; DefaultPartitioner has no per-domain validation rules, so it's the same check regardless of
+// domain.
+func (r *DefaultPartitioner) ValidateStartWorkflowPartitionConfig(domain string, key types.PartitionConfig) error {
+	return r.Validate(key)
+}
+
 func (r *DefaultPartitioner) GetTaskZone(ctx context.Context, DomainID string, key types.PartitionConfig) (*types.ZoneName, error) {
 	partitionData := DefaultPartitionConfig{}
 	err := json.Unmarshal(key, &partitionData)
@@ -85,7 +134,14 @@ func (r *DefaultPartitioner) GetTaskZone(ctx context.Context, DomainID string, k
 		if err != nil {
 			return nil, fmt.Errorf("failed to list all zones: %w", err)
 		}
-		zone := pickZoneAfterDrain(zones, partitionData)
+		policy, err := r.drainState.GetPolicy(ctx, DomainID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get zone placement policy: %w", err)
+		}
+		zone, err := pickZoneAfterDrain(zones, partitionData, policy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pick a replacement zone: %w", err)
+		}
 		return &zone, nil
 	}
 
@@ -128,7 +184,7 @@ func (z *DefaultZoneStateHandler) Get(ctx context.Context, domain string, zone t
 		return nil, fmt.Errorf("could not resolve domain in zone handler: %w", err)
 	}
 	cfg, ok := domainData.GetInfo().ZoneConfig[zone]
-	if ok && cfg.Status == types.ZoneDrainStatusDrained {
+	if ok && isDrainingOrDrained(cfg.Status) {
 		return &cfg, nil
 	}
 
@@ -147,15 +203,129 @@ func (z *DefaultZoneStateHandler) Get(ctx context.Context, domain string, zone t
 	}, nil
 }
 
-// Simple deterministic zone picker
-// which will pick a random healthy zone and place the workflow there
-func pickZoneAfterDrain(zones []types.ZonePartition, wfConfig DefaultPartitionConfig) types.ZoneName {
+// GetPolicy returns the operator-configured affinity/spread policy for domainID. The domain cache is only
+// used to resolve domainID to the domain name policies are stored under; the policy itself is read from
+// domainZonePolicies so a policy update is visible immediately rather than waiting on the cache's refresh
+// cycle - the same reason global drain state is read from globalZoneDrains instead of being folded into
+// domain config. A domain with no configured policy is not an error - it simply has no soft preferences.
+func (z *DefaultZoneStateHandler) GetPolicy(ctx context.Context, domainID string) (*types.ZonePlacementPolicy, error) {
+	domain, err := z.domainCache.GetDomainByID(domainID)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve domain in zone handler: %w", err)
+	}
+	policy, err := z.domainZonePolicies.GetZonePlacementPolicy(ctx, domain.GetInfo().Name)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve zone placement policy: %w", err)
+	}
+	return policy, nil
+}
+
+// errNoHealthyZones is returned by pickZoneAfterDrain when every known zone is draining or drained, so
+// there is no candidate left to reassign a workflow to.
+var errNoHealthyZones = errors.New("no healthy zone available to route to")
+
+// pickZoneAfterDrain picks a healthy replacement zone via weighted rendezvous hashing: each candidate's
+// weight combines its configured affinity match with its spread target, and rendezvousPick resolves a
+// winner from those weights. It is a pure function of zones, wfConfig and policy - the same RunID against
+// the same candidate set always resolves to the same zone, on every matching host, so a single workflow's
+// tasks are never split across zones and draining a zone only reassigns the workflows that were mapped to
+// it.
+//
+// Spread targets (types.ZoneSpread) bias selection the same way: a zone's target Percent becomes part of
+// its rendezvous weight, so across the population of RunIDs a zone configured for a higher share of
+// placements wins more often - without depending on any live, per-process placement counter that would
+// break the determinism above. The resulting shares are not proportional to Percent: each zone's hash
+// score is scaled linearly by its weight, which favours the highest-weighted zone by more than its ratio.
+func pickZoneAfterDrain(zones []types.ZonePartition, wfConfig DefaultPartitionConfig, policy *types.ZonePlacementPolicy) (types.ZoneName, error) {
 	var availableZones []types.ZoneName
 	for _, zone := range zones {
 		if zone.Status == types.ZoneDrainStatusHealthy {
 			availableZones = append(availableZones, zone.Name)
 		}
 	}
-	hashv := farm.Hash32([]byte(wfConfig.RunID))
-	return availableZones[int(hashv)%len(availableZones)]
+	if len(availableZones) == 0 {
+		return "", errNoHealthyZones
+	}
+
+	weights := make(map[types.ZoneName]float64, len(availableZones))
+	for _, zone := range availableZones {
+		weights[zone] = zoneWeight(zone, wfConfig, policy)
+	}
+	return rendezvousPick(wfConfig.RunID, availableZones, weights), nil
+}
+
+// zoneWeight combines zone's affinity match and spread target into the weight rendezvousPick biases its
+// selection by.
+func zoneWeight(zone types.ZoneName, wfConfig DefaultPartitionConfig, policy *types.ZonePlacementPolicy) float64 {
+	weight := 1.0 + affinityWeight(zone, wfConfig, policy)
+	if policy != nil && len(policy.Spreads) > 0 {
+		weight *= spreadTarget(zone, policy)
+	}
+	return weight
+}
+
+func affinityWeight(zone types.ZoneName, wfConfig DefaultPartitionConfig, policy *types.ZonePlacementPolicy) float64 {
+	if policy == nil {
+		return 0
+	}
+	var total float64
+	for _, affinity := range policy.Affinities {
+		if affinity.Zone != zone {
+			continue
+		}
+		if wfConfig.Attributes[affinity.Attribute] == affinity.Value {
+			total += float64(affinity.Weight)
+		}
+	}
+	return total
+}
+
+// spreadTarget returns zone's configured spread percentage, or 0 if policy has no entry for it - per
+// types.ZoneSpread's doc, zones with no entry are treated as having a target of 0.
+func spreadTarget(zone types.ZoneName, policy *types.ZonePlacementPolicy) float64 {
+	for _, spread := range policy.Spreads {
+		if spread.Zone == zone {
+			return float64(spread.Percent)
+		}
+	}
+	return 0
+}
+
+// rendezvousPick selects a zone from zones for runID using highest-random-weight (rendezvous) hashing:
+// each zone gets an independent score derived from hashing runID together with the zone's name, and the
+// zone with the maximum score wins. Unlike a mod-N hash over the candidate set, only runIDs whose winner
+// was a zone that's no longer a candidate get reassigned when the set shrinks - O(1/N) churn rather than
+// O((N-1)/N).
+//
+// weights optionally biases the outcome: if non-empty, each zone's score is scaled by weight/maxWeight
+// before comparison, and zones absent from weights score zero. A nil/empty weights, or one with no
+// positive entry, leaves every zone unweighted. pickZoneAfterDrain uses this to fold affinity and spread
+// targets into the same reassignment-stable hashing rather than layering a second, less stable, selection
+// mechanism on top.
+func rendezvousPick(runID string, zones []types.ZoneName, weights map[types.ZoneName]float64) types.ZoneName {
+	maxWeight := 0.0
+	for _, w := range weights {
+		if w > maxWeight {
+			maxWeight = w
+		}
+	}
+
+	var best types.ZoneName
+	bestScore := -1.0
+	for _, zone := range zones {
+		score := float64(farm.Hash64WithSeed([]byte(runID), farm.Hash64([]byte(zone))))
+		if maxWeight > 0 {
+			w, ok := weights[zone]
+			if !ok {
+				score = 0
+			} else {
+				score *= w / maxWeight
+			}
+		}
+		if score > bestScore || (score == bestScore && (best == "" || zone < best)) {
+			bestScore = score
+			best = zone
+		}
+	}
+	return best
 }
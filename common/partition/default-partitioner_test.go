@@ -0,0 +1,130 @@
+package partition
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/types"
+)
+
+// TestRendezvousPick_DrainOnlyMovesAffectedWorkflows proves the core property rendezvous hashing is
+// chosen for: draining a zone only reassigns the workflows that were previously mapped to it - every
+// other workflow keeps its prior zone.
+func TestRendezvousPick_DrainOnlyMovesAffectedWorkflows(t *testing.T) {
+	allZones := []types.ZoneName{"z1", "z2", "z3", "z4", "z5"}
+	drainedZone := types.ZoneName("z3")
+
+	var remainingZones []types.ZoneName
+	for _, zone := range allZones {
+		if zone != drainedZone {
+			remainingZones = append(remainingZones, zone)
+		}
+	}
+
+	runIDs := make([]string, 500)
+	for i := range runIDs {
+		runIDs[i] = fmt.Sprintf("run-id-%d", i)
+	}
+
+	for _, runID := range runIDs {
+		t.Run(runID, func(t *testing.T) {
+			before := rendezvousPick(runID, allZones, nil)
+			after := rendezvousPick(runID, remainingZones, nil)
+
+			if before != drainedZone {
+				assert.Equal(t, before, after, "workflow not previously assigned to the drained zone should not move")
+			} else {
+				assert.NotEqual(t, drainedZone, after, "workflow previously assigned to the drained zone must move")
+			}
+		})
+	}
+}
+
+func TestRendezvousPick_Deterministic(t *testing.T) {
+	zones := []types.ZoneName{"z1", "z2", "z3"}
+	first := rendezvousPick("some-run-id", zones, nil)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first, rendezvousPick("some-run-id", zones, nil))
+	}
+}
+
+// TestPickZoneAfterDrain_Deterministic proves pickZoneAfterDrain - the actual production path GetTaskZone
+// calls - is a pure function of its inputs: repeated calls with the same zones/wfConfig/policy always
+// return the same zone, regardless of call order or how many times it's been called before. This is the
+// property that guarantees every matching host resolves the same zone for the same workflow.
+func TestPickZoneAfterDrain_Deterministic(t *testing.T) {
+	zones := []types.ZonePartition{
+		{Name: "z1", Status: types.ZoneDrainStatusHealthy},
+		{Name: "z2", Status: types.ZoneDrainStatusHealthy},
+		{Name: "z3", Status: types.ZoneDrainStatusDrained},
+	}
+	policy := &types.ZonePlacementPolicy{
+		Affinities: []types.ZoneAffinity{
+			{Zone: "z2", Attribute: "tenant", Value: "gold", Weight: 10},
+		},
+	}
+	wfConfig := DefaultPartitionConfig{RunID: "some-run-id", Attributes: map[string]string{"tenant": "gold"}}
+
+	first, err := pickZoneAfterDrain(zones, wfConfig, policy)
+	assert.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		got, err := pickZoneAfterDrain(zones, wfConfig, policy)
+		assert.NoError(t, err)
+		assert.Equal(t, first, got)
+	}
+	assert.Equal(t, types.ZoneName("z2"), first, "affinity-matching zone should win the score")
+}
+
+// TestPickZoneAfterDrain_NoHealthyZones proves the empty-candidate-set case returns errNoHealthyZones
+// instead of picking a zone.
+func TestPickZoneAfterDrain_NoHealthyZones(t *testing.T) {
+	zones := []types.ZonePartition{
+		{Name: "z1", Status: types.ZoneDrainStatusDrained},
+		{Name: "z2", Status: types.ZoneDrainStatusPreparing},
+	}
+	_, err := pickZoneAfterDrain(zones, DefaultPartitionConfig{RunID: "some-run-id"}, nil)
+	assert.ErrorIs(t, err, errNoHealthyZones)
+}
+
+// TestPickZoneAfterDrain_OnlyDrainedZoneMoves proves draining a zone only reassigns the workflows that
+// were mapped to it, through pickZoneAfterDrain - the function GetTaskZone calls - rather than just the
+// rendezvousPick helper, with an affinity policy configured so unweighted scores aren't the only thing
+// being exercised.
+func TestPickZoneAfterDrain_OnlyDrainedZoneMoves(t *testing.T) {
+	allZones := []types.ZonePartition{
+		{Name: "z1", Status: types.ZoneDrainStatusHealthy},
+		{Name: "z2", Status: types.ZoneDrainStatusHealthy},
+		{Name: "z3", Status: types.ZoneDrainStatusHealthy},
+		{Name: "z4", Status: types.ZoneDrainStatusHealthy},
+		{Name: "z5", Status: types.ZoneDrainStatusHealthy},
+	}
+	var afterDrain []types.ZonePartition
+	for _, z := range allZones {
+		status := z.Status
+		if z.Name == "z3" {
+			status = types.ZoneDrainStatusDrained
+		}
+		afterDrain = append(afterDrain, types.ZonePartition{Name: z.Name, Status: status})
+	}
+	policy := &types.ZonePlacementPolicy{
+		Affinities: []types.ZoneAffinity{
+			{Zone: "z1", Attribute: "tenant", Value: "gold", Weight: 5},
+		},
+	}
+
+	for i := 0; i < 200; i++ {
+		wfConfig := DefaultPartitionConfig{RunID: fmt.Sprintf("run-id-%d", i), Attributes: map[string]string{"tenant": "gold"}}
+		before, err := pickZoneAfterDrain(allZones, wfConfig, policy)
+		assert.NoError(t, err)
+		after, err := pickZoneAfterDrain(afterDrain, wfConfig, policy)
+		assert.NoError(t, err)
+
+		if before != "z3" {
+			assert.Equal(t, before, after, "workflow not previously assigned to the drained zone should not move")
+		} else {
+			assert.NotEqual(t, types.ZoneName("z3"), after, "workflow previously assigned to the drained zone must move")
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package partition
+
+import (
+	"context"
+
+	"github.com/uber/cadence/common/types"
+)
+
+// Partitioner resolves the zone that a workflow's tasks should be routed to, taking into account
+// zone drain state and any operator-configured placement policy.
+type Partitioner interface {
+	// IsDrained returns whether zone should be avoided for *new* placements in domain: a zone that is
+	// still Preparing a drain counts as drained here, even though in-flight work assigned to it is left
+	// alone until the drain actually commits - see IsFullyDrainedByDomainID for that distinction.
+	IsDrained(ctx context.Context, domain string, zone types.ZoneName) (bool, error)
+	// IsDrainedByDomainID is IsDrained, but resolving the domain by ID rather than name.
+	IsDrainedByDomainID(ctx context.Context, domainID string, zone types.ZoneName) (bool, error)
+	// IsFullyDrainedByDomainID reports whether zone has completed its drain for domainID - unlike
+	// IsDrainedByDomainID, a zone that is only Preparing returns false here. Callers deciding whether to
+	// reject/forward an append for a task already in flight should use this, not IsDrainedByDomainID,
+	// so a Preparing zone keeps serving its existing work right up until the drain commits.
+	IsFullyDrainedByDomainID(ctx context.Context, domainID string, zone types.ZoneName) (bool, error)
+	// GetTaskZone returns the zone that a workflow matching key should be routed to.
+	GetTaskZone(ctx context.Context, domainID string, key types.PartitionConfig) (*types.ZoneName, error)
+	// ValidateStartWorkflowPartitionConfig returns an error if key is not a well-formed PartitionConfig for
+	// domain's active strategy, so a malformed config can be rejected at start-workflow time rather than
+	// failing silently later during task routing.
+	ValidateStartWorkflowPartitionConfig(domain string, key types.PartitionConfig) error
+}
+
+// ZoneState tracks the health/drain status of zones on behalf of a Partitioner, combining domain-level
+// and global (cluster-wide) drain configuration.
+type ZoneState interface {
+	// Get returns the current ZonePartition for zone within domain.
+	Get(ctx context.Context, domain string, zone types.ZoneName) (*types.ZonePartition, error)
+	// GetByDomainID is Get, but resolving the domain by ID rather than name.
+	GetByDomainID(ctx context.Context, domainID string, zone types.ZoneName) (*types.ZonePartition, error)
+	// ListAll returns the ZonePartition of every known zone for domainID.
+	ListAll(ctx context.Context, domainID string) ([]types.ZonePartition, error)
+	// GetPolicy returns the operator-configured affinity/spread policy for domainID, or nil if none is set.
+	GetPolicy(ctx context.Context, domainID string) (*types.ZonePlacementPolicy, error)
+}
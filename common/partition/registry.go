@@ -0,0 +1,167 @@
+package partition
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/types"
+)
+
+// defaultStrategyName is the name the built-in DefaultPartitioner is auto-registered under, and the name
+// used by domains that haven't configured an active strategy.
+const defaultStrategyName = "default"
+
+// Strategy is a named, pluggable zone-selection algorithm: a Partitioner that also knows how to validate
+// the PartitionConfig a start-workflow request wants to use with it, so malformed configs can be rejected
+// at start-workflow time rather than failing silently during task routing.
+type Strategy interface {
+	Partitioner
+	// Validate returns an error if key is not a well-formed PartitionConfig for this strategy.
+	Validate(key types.PartitionConfig) error
+}
+
+// Registry lets operators register named Partitioner strategies at process start and select the active
+// one per-domain via Config, decoupling the core partitioning code from strategy evolution.
+type Registry struct {
+	mu         sync.RWMutex
+	strategies map[string]Strategy
+}
+
+// NewRegistry returns an empty strategy registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		strategies: make(map[string]Strategy),
+	}
+}
+
+// NewDefaultRegistry returns a Registry with the built-in DefaultPartitioner already registered under
+// "default", so existing deployments that never configure a custom strategy keep behaving unchanged.
+func NewDefaultRegistry(logger log.Logger) (*Registry, error) {
+	registry := NewRegistry()
+	defaultStrategy, ok := NewDefaultTaskResolver(logger).(Strategy)
+	if !ok {
+		return nil, fmt.Errorf("DefaultPartitioner does not implement Strategy")
+	}
+	if err := registry.Register(defaultStrategyName, defaultStrategy); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// Register adds strategy under name. It is an error to register the same name twice.
+func (r *Registry) Register(name string, strategy Strategy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.strategies[name]; exists {
+		return fmt.Errorf("partitioner strategy %q is already registered", name)
+	}
+	r.strategies[name] = strategy
+	return nil
+}
+
+// Get returns the strategy registered under name.
+func (r *Registry) Get(name string) (Strategy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	strategy, ok := r.strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("no partitioner strategy registered under %q", name)
+	}
+	return strategy, nil
+}
+
+// registryPartitioner is the Partitioner handed out for a Registry-backed setup: it resolves the active
+// strategy for the domain on every call via Config and forwards to it. Domains with no configured
+// strategy fall back to "default", so callers of GetTaskZone work unchanged.
+type registryPartitioner struct {
+	registry    *Registry
+	config      Config
+	domainCache cache.DomainCache
+}
+
+// NewRegistryPartitioner returns a Partitioner that dispatches to registry's strategies, chosen per-domain
+// via config's active-strategy property. domainCache resolves domain IDs to names for the ID-keyed entry
+// points, since config's active-strategy property is keyed by domain name.
+func NewRegistryPartitioner(registry *Registry, config Config, domainCache cache.DomainCache) Partitioner {
+	return &registryPartitioner{registry: registry, config: config, domainCache: domainCache}
+}
+
+func (p *registryPartitioner) strategyForDomain(domain string) (Strategy, error) {
+	name := defaultStrategyName
+	if p.config.activeStrategy != nil {
+		if configured := p.config.activeStrategy(domain); configured != "" {
+			name = configured
+		}
+	}
+	strategy, err := p.registry.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve partitioner strategy for domain %q: %w", domain, err)
+	}
+	return strategy, nil
+}
+
+// resolveDomainName resolves domainID to the domain name that config.activeStrategy is keyed by, mirroring
+// the same ID->name resolution DefaultZoneStateHandler.GetByDomainID does before consulting per-domain
+// config.
+func (p *registryPartitioner) resolveDomainName(domainID string) (string, error) {
+	domain, err := p.domainCache.GetDomainByID(domainID)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve domain in registry partitioner: %w", err)
+	}
+	return domain.GetInfo().Name, nil
+}
+
+func (p *registryPartitioner) IsDrained(ctx context.Context, domain string, zone types.ZoneName) (bool, error) {
+	strategy, err := p.strategyForDomain(domain)
+	if err != nil {
+		return false, err
+	}
+	return strategy.IsDrained(ctx, domain, zone)
+}
+
+func (p *registryPartitioner) IsDrainedByDomainID(ctx context.Context, domainID string, zone types.ZoneName) (bool, error) {
+	domainName, err := p.resolveDomainName(domainID)
+	if err != nil {
+		return false, err
+	}
+	strategy, err := p.strategyForDomain(domainName)
+	if err != nil {
+		return false, err
+	}
+	return strategy.IsDrainedByDomainID(ctx, domainID, zone)
+}
+
+func (p *registryPartitioner) IsFullyDrainedByDomainID(ctx context.Context, domainID string, zone types.ZoneName) (bool, error) {
+	domainName, err := p.resolveDomainName(domainID)
+	if err != nil {
+		return false, err
+	}
+	strategy, err := p.strategyForDomain(domainName)
+	if err != nil {
+		return false, err
+	}
+	return strategy.IsFullyDrainedByDomainID(ctx, domainID, zone)
+}
+
+func (p *registryPartitioner) ValidateStartWorkflowPartitionConfig(domain string, key types.PartitionConfig) error {
+	strategy, err := p.strategyForDomain(domain)
+	if err != nil {
+		return err
+	}
+	return strategy.Validate(key)
+}
+
+func (p *registryPartitioner) GetTaskZone(ctx context.Context, domainID string, key types.PartitionConfig) (*types.ZoneName, error) {
+	domainName, err := p.resolveDomainName(domainID)
+	if err != nil {
+		return nil, err
+	}
+	strategy, err := p.strategyForDomain(domainName)
+	if err != nil {
+		return nil, err
+	}
+	return strategy.GetTaskZone(ctx, domainID, key)
+}
@@ -0,0 +1,135 @@
+package partition
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/types"
+)
+
+// fakeStrategy is a minimal Strategy that reports which domain it was called with, so tests can assert the
+// registry dispatched to the right one.
+type fakeStrategy struct {
+	name        string
+	calledWith  string
+	isDrainedOK bool
+	validateErr error
+}
+
+func (s *fakeStrategy) IsDrained(ctx context.Context, domain string, zone types.ZoneName) (bool, error) {
+	s.calledWith = domain
+	return s.isDrainedOK, nil
+}
+
+func (s *fakeStrategy) IsDrainedByDomainID(ctx context.Context, domainID string, zone types.ZoneName) (bool, error) {
+	s.calledWith = domainID
+	return s.isDrainedOK, nil
+}
+
+func (s *fakeStrategy) IsFullyDrainedByDomainID(ctx context.Context, domainID string, zone types.ZoneName) (bool, error) {
+	s.calledWith = domainID
+	return s.isDrainedOK, nil
+}
+
+func (s *fakeStrategy) GetTaskZone(ctx context.Context, domainID string, key types.PartitionConfig) (*types.ZoneName, error) {
+	s.calledWith = domainID
+	zone := types.ZoneName("z1")
+	return &zone, nil
+}
+
+func (s *fakeStrategy) Validate(key types.PartitionConfig) error {
+	return s.validateErr
+}
+
+func (s *fakeStrategy) ValidateStartWorkflowPartitionConfig(domain string, key types.PartitionConfig) error {
+	return s.Validate(key)
+}
+
+// fakeDomainCache resolves exactly one domainID -> name mapping, for tests.
+type fakeDomainCache struct {
+	idToName map[string]string
+}
+
+func (c *fakeDomainCache) GetDomain(name string) (*cache.DomainCacheEntry, error) {
+	return cache.NewDomainCacheEntryForTest(&cache.DomainInfo{Name: name}), nil
+}
+
+func (c *fakeDomainCache) GetDomainByID(id string) (*cache.DomainCacheEntry, error) {
+	return cache.NewDomainCacheEntryForTest(&cache.DomainInfo{Name: c.idToName[id]}), nil
+}
+
+func TestRegistry_RegisterAndGet(t *testing.T) {
+	registry := NewRegistry()
+	strategy := &fakeStrategy{name: "custom"}
+
+	_, err := registry.Get("custom")
+	assert.Error(t, err, "unregistered strategy should error")
+
+	require.NoError(t, registry.Register("custom", strategy))
+	got, err := registry.Get("custom")
+	require.NoError(t, err)
+	assert.Same(t, strategy, got)
+
+	assert.Error(t, registry.Register("custom", strategy), "re-registering the same name should error")
+}
+
+func TestRegistryPartitioner_ResolvesDomainNameForIDEntryPoints(t *testing.T) {
+	registry := NewRegistry()
+	strategy := &fakeStrategy{name: "custom", isDrainedOK: true}
+	require.NoError(t, registry.Register(defaultStrategyName, strategy))
+
+	domainCache := &fakeDomainCache{idToName: map[string]string{"domain-id-123": "my-domain"}}
+	partitioner := NewRegistryPartitioner(registry, Config{}, domainCache)
+
+	drained, err := partitioner.IsDrainedByDomainID(context.Background(), "domain-id-123", "z1")
+	require.NoError(t, err)
+	assert.True(t, drained)
+	assert.Equal(t, "domain-id-123", strategy.calledWith, "strategy itself still receives the raw domainID")
+
+	_, err = partitioner.GetTaskZone(context.Background(), "domain-id-123", types.PartitionConfig(`{}`))
+	require.NoError(t, err)
+}
+
+func TestRegistryPartitioner_NameEntryPointUsesDomainDirectly(t *testing.T) {
+	registry := NewRegistry()
+	strategy := &fakeStrategy{name: "custom", isDrainedOK: true}
+	require.NoError(t, registry.Register(defaultStrategyName, strategy))
+
+	partitioner := NewRegistryPartitioner(registry, Config{}, &fakeDomainCache{})
+
+	drained, err := partitioner.IsDrained(context.Background(), "my-domain", "z1")
+	require.NoError(t, err)
+	assert.True(t, drained)
+	assert.Equal(t, "my-domain", strategy.calledWith)
+}
+
+func TestRegistryPartitioner_IsFullyDrainedByDomainIDResolvesDomainName(t *testing.T) {
+	registry := NewRegistry()
+	strategy := &fakeStrategy{name: "custom", isDrainedOK: true}
+	require.NoError(t, registry.Register(defaultStrategyName, strategy))
+
+	domainCache := &fakeDomainCache{idToName: map[string]string{"domain-id-123": "my-domain"}}
+	partitioner := NewRegistryPartitioner(registry, Config{}, domainCache)
+
+	drained, err := partitioner.IsFullyDrainedByDomainID(context.Background(), "domain-id-123", "z1")
+	require.NoError(t, err)
+	assert.True(t, drained)
+	assert.Equal(t, "domain-id-123", strategy.calledWith, "strategy itself still receives the raw domainID")
+}
+
+func TestRegistryPartitioner_ValidateStartWorkflowPartitionConfigForwardsToActiveStrategy(t *testing.T) {
+	registry := NewRegistry()
+	wantErr := fmt.Errorf("malformed config")
+	strategy := &fakeStrategy{name: "custom", validateErr: wantErr}
+	require.NoError(t, registry.Register(defaultStrategyName, strategy))
+
+	partitioner := NewRegistryPartitioner(registry, Config{}, &fakeDomainCache{})
+
+	err := partitioner.ValidateStartWorkflowPartitionConfig("my-domain", types.PartitionConfig(`{}`))
+	assert.Equal(t, wantErr, err)
+}
@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/types"
+)
+
+// DomainConfigStore is the persistence surface operators configure zone-aware partitioning's soft
+// affinity/spread preferences through: reading and updating a domain's ZonePlacementPolicy independently
+// of the domain cache refresh cycle, the same way GlobalZoneDrains decouples zone drain state from it, so
+// a policy change takes effect as soon as it's written rather than on the cache's next refresh.
+type DomainConfigStore interface {
+	// GetZonePlacementPolicy returns domain's currently configured policy, or nil if none has been set.
+	GetZonePlacementPolicy(ctx context.Context, domain string) (*types.ZonePlacementPolicy, error)
+	// UpdateZonePlacementPolicy replaces domain's configured policy. Passing nil clears it.
+	UpdateZonePlacementPolicy(ctx context.Context, domain string, policy *types.ZonePlacementPolicy) error
+}
+
+// DomainZonePolicySerializer encodes/decodes the blob persisted in the DomainZonePolicyConfig row.
+type DomainZonePolicySerializer interface {
+	SerializeDomainZonePolicies(blob *domainZonePoliciesBlob) ([]byte, error)
+	DeserializeDomainZonePolicies(data []byte) (*domainZonePoliciesBlob, error)
+}
+
+// domainZonePoliciesBlob is the full payload persisted in the DomainZonePolicyConfig row: every domain
+// with a configured policy, keyed by domain name.
+type domainZonePoliciesBlob struct {
+	Policies map[string]*types.ZonePlacementPolicy
+}
+
+type configStoreDomainZonePolicy struct {
+	serializer    DomainZonePolicySerializer
+	persistence   ConfigStore
+	throttleRetry *backoff.ThrottleRetry
+}
+
+// NewDomainConfigStore creates a DomainConfigStore backed by a ConfigStore row, mirroring
+// NewGlobalZoneDrains's read-modify-write-with-CAS-retry pattern.
+func NewDomainConfigStore(serializer DomainZonePolicySerializer, store ConfigStore) DomainConfigStore {
+	return &configStoreDomainZonePolicy{
+		serializer:  serializer,
+		persistence: store,
+		throttleRetry: backoff.NewThrottleRetry(
+			backoff.WithRetryPolicy(configStoreOperationRetryPolicy),
+			backoff.WithRetryableError(IsTransientError),
+		),
+	}
+}
+
+func (d *configStoreDomainZonePolicy) GetZonePlacementPolicy(ctx context.Context, domain string) (*types.ZonePlacementPolicy, error) {
+	blob, _, err := d.load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load domain zone policies: %w", err)
+	}
+	return blob.Policies[domain], nil
+}
+
+func (d *configStoreDomainZonePolicy) UpdateZonePlacementPolicy(ctx context.Context, domain string, policy *types.ZonePlacementPolicy) error {
+	op := func() error {
+		blob, version, err := d.load(ctx)
+		if err != nil {
+			return err
+		}
+		if policy == nil {
+			delete(blob.Policies, domain)
+		} else {
+			blob.Policies[domain] = policy
+		}
+		payload, err := d.serializer.SerializeDomainZonePolicies(blob)
+		if err != nil {
+			return err
+		}
+		return d.persistence.UpdateConfig(ctx, &InternalConfigStoreEntry{
+			RowType:   DomainZonePolicyConfig,
+			Version:   version,
+			Timestamp: time.Now(),
+			Values:    payload,
+		})
+	}
+	if err := d.throttleRetry.Do(ctx, op); err != nil {
+		return fmt.Errorf("failed to update zone placement policy for domain %v: %w", domain, err)
+	}
+	return nil
+}
+
+// load fetches and decodes the current blob, returning a zero-value blob and version 0 if no row has been
+// written yet.
+func (d *configStoreDomainZonePolicy) load(ctx context.Context) (*domainZonePoliciesBlob, int64, error) {
+	entry, err := d.persistence.FetchConfig(ctx, DomainZonePolicyConfig)
+	if errors.Is(err, ErrConfigStoreEntryNotFound) {
+		return &domainZonePoliciesBlob{Policies: make(map[string]*types.ZonePlacementPolicy)}, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	blob, err := d.serializer.DeserializeDomainZonePolicies(entry.Values)
+	if err != nil {
+		return nil, 0, err
+	}
+	if blob.Policies == nil {
+		blob.Policies = make(map[string]*types.ZonePlacementPolicy)
+	}
+	return blob, entry.Version, nil
+}
@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/types"
+)
+
+// jsonDomainZonePolicySerializer is a minimal DomainZonePolicySerializer used only by tests.
+type jsonDomainZonePolicySerializer struct{}
+
+func (jsonDomainZonePolicySerializer) SerializeDomainZonePolicies(blob *domainZonePoliciesBlob) ([]byte, error) {
+	return json.Marshal(blob)
+}
+
+func (jsonDomainZonePolicySerializer) DeserializeDomainZonePolicies(data []byte) (*domainZonePoliciesBlob, error) {
+	var blob domainZonePoliciesBlob
+	if err := json.Unmarshal(data, &blob); err != nil {
+		return nil, err
+	}
+	return &blob, nil
+}
+
+func newTestDomainConfigStore() DomainConfigStore {
+	return NewDomainConfigStore(jsonDomainZonePolicySerializer{}, &fakeConfigStore{})
+}
+
+func TestDomainConfigStore_GetUnsetPolicyReturnsNil(t *testing.T) {
+	ctx := context.Background()
+	store := newTestDomainConfigStore()
+
+	policy, err := store.GetZonePlacementPolicy(ctx, "my-domain")
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+}
+
+func TestDomainConfigStore_UpdateAndGet(t *testing.T) {
+	ctx := context.Background()
+	store := newTestDomainConfigStore()
+
+	policy := &types.ZonePlacementPolicy{
+		Affinities: []types.ZoneAffinity{{Zone: "z1", Attribute: "tenant", Value: "gold", Weight: 5}},
+		Spreads:    []types.ZoneSpread{{Zone: "z2", Percent: 50}},
+	}
+	require.NoError(t, store.UpdateZonePlacementPolicy(ctx, "my-domain", policy))
+
+	got, err := store.GetZonePlacementPolicy(ctx, "my-domain")
+	require.NoError(t, err)
+	assert.Equal(t, policy, got)
+
+	// A different domain's policy is unaffected.
+	other, err := store.GetZonePlacementPolicy(ctx, "other-domain")
+	require.NoError(t, err)
+	assert.Nil(t, other)
+}
+
+func TestDomainConfigStore_UpdateWithNilClearsPolicy(t *testing.T) {
+	ctx := context.Background()
+	store := newTestDomainConfigStore()
+
+	policy := &types.ZonePlacementPolicy{Affinities: []types.ZoneAffinity{{Zone: "z1", Weight: 1}}}
+	require.NoError(t, store.UpdateZonePlacementPolicy(ctx, "my-domain", policy))
+	require.NoError(t, store.UpdateZonePlacementPolicy(ctx, "my-domain", nil))
+
+	got, err := store.GetZonePlacementPolicy(ctx, "my-domain")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
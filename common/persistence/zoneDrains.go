@@ -2,35 +2,291 @@ package persistence
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/types"
-	"time"
 )
 
+// ErrConfigStoreEntryNotFound is returned by ConfigStore.FetchConfig when no row has been written yet for
+// the requested ConfigType.
+var ErrConfigStoreEntryNotFound = errors.New("config store entry not found")
+
+// ErrDrainNotPreparing is returned by AckDrain/CommitDrain when zone has no in-flight prepare.
+var ErrDrainNotPreparing = errors.New("zone is not preparing a drain")
+
+// ErrDrainGenerationMismatch is returned by CommitDrain when the commit is made against a generation other
+// than the zone's current prepare generation - typically because a newer PrepareDrain superseded it.
+var ErrDrainGenerationMismatch = errors.New("drain generation does not match current prepare")
+
+// ErrDrainLeaseExpired is returned by CommitDrain when generation's lease has already expired at the time
+// of the commit, whether or not every required host acknowledged it.
+var ErrDrainLeaseExpired = errors.New("drain prepare lease expired before the drain was committed")
+
+// ErrDrainMissingAcks is returned by CommitDrain when generation's lease has not expired but not every
+// required host has acknowledged it yet.
+var ErrDrainMissingAcks = errors.New("not all required hosts have acknowledged the drain prepare")
+
+// ConfigType identifies the logical row stored in a ConfigStore-backed table.
+type ConfigType int
+
+const (
+	// ZonalConfig is the row holding global (cluster-wide) zone drain state.
+	ZonalConfig ConfigType = iota
+	// DomainZonePolicyConfig is the row holding every domain's configured ZonePlacementPolicy.
+	DomainZonePolicyConfig
+)
+
+// InternalConfigStoreEntry is a single versioned row in a ConfigStore-backed table. Version is used for
+// compare-and-swap: UpdateConfig must fail with a retryable error if the row's current version does not
+// match the version the caller last read it at.
+type InternalConfigStoreEntry struct {
+	RowType   ConfigType
+	Version   int64
+	Timestamp time.Time
+	Values    []byte
+}
+
+// ConfigStore is the narrow persistence surface GlobalZoneDrains is built on: a single versioned blob per
+// ConfigType, updated via optimistic concurrency control.
+type ConfigStore interface {
+	FetchConfig(ctx context.Context, rowType ConfigType) (*InternalConfigStoreEntry, error)
+	UpdateConfig(ctx context.Context, entry *InternalConfigStoreEntry) error
+}
+
+// PayloadSerializer encodes/decodes the blobs persisted in ConfigStore rows.
+type PayloadSerializer interface {
+	SerializeClusterZoneDrains(blob *clusterZoneDrainsBlob) ([]byte, error)
+	DeserializeClusterZoneDrains(data []byte) (*clusterZoneDrainsBlob, error)
+}
+
+// GlobalZoneDrains coordinates cluster-wide zone drains through a two-phase prepare/commit protocol, so
+// that draining a zone only takes effect once the hosts that need to stop routing to it have acknowledged.
 type GlobalZoneDrains interface {
+	// GetClusterDrains returns the current ZonePartition of every zone with a non-default state, whether
+	// Preparing or Drained.
 	GetClusterDrains(ctx context.Context) (map[types.ZoneName]types.ZonePartition, error)
-	SetClusterDrains(ctx context.Context, partition types.ZonePartition) error
+	// PrepareDrain starts draining zone: it writes a Preparing entry with a lease of ttl and the set of
+	// host-ids required to acknowledge before the drain can commit, and returns the generation that acks
+	// must be tagged with. A zone already Preparing is re-prepared with a new generation, invalidating acks
+	// recorded against the old one.
+	PrepareDrain(ctx context.Context, zone types.ZoneName, requiredHosts []string, ttl time.Duration) (generation int64, err error)
+	// AckDrain records that hostID has acknowledged generation's prepare for zone. Acks for a generation
+	// other than the zone's current one are ignored rather than erroring, since they are expected whenever
+	// a prepare is superseded while acks are still in flight. Any ack for a zone that is not Preparing
+	// returns ErrDrainNotPreparing, whatever its generation.
+	AckDrain(ctx context.Context, zone types.ZoneName, hostID string, generation int64) error
+	// CommitDrain flips zone to Drained if it is called before generation's lease expires and every
+	// required host has acked generation by then.
+	CommitDrain(ctx context.Context, zone types.ZoneName, generation int64) error
+	// AbortDrain restores zone to Healthy and evicts its prepare row.
+	AbortDrain(ctx context.Context, zone types.ZoneName) error
+}
+
+// zoneDrainState is the per-zone state backing the two-phase drain protocol. RequiredHosts/Acks are only
+// meaningful while Status is Preparing.
+type zoneDrainState struct {
+	Status        types.ZoneStatus
+	Generation    int64
+	LeaseExpiry   time.Time
+	RequiredHosts map[string]struct{}
+	Acks          map[string]struct{}
+}
+
+func (s *zoneDrainState) toZonePartition(zone types.ZoneName) types.ZonePartition {
+	return types.ZonePartition{Name: zone, Status: s.Status}
+}
+
+func (s *zoneDrainState) hasQuorum() bool {
+	for host := range s.RequiredHosts {
+		if _, acked := s.Acks[host]; !acked {
+			return false
+		}
+	}
+	return true
+}
+
+// clusterZoneDrainsBlob is the full payload persisted in the ZonalConfig row.
+type clusterZoneDrainsBlob struct {
+	Zones map[types.ZoneName]*zoneDrainState
 }
 
 type globalZoneDrainsImpl struct {
-	serializer  PayloadSerializer
-	persistence ConfigStore
-	logger      log.Logger
-}
-
-func (z *globalZoneDrainsImpl) SetClusterDrains(ctx context.Context, partition types.ZonePartition) error {
-	panic("not implemented")
-	z.persistence.UpdateConfig(ctx, &InternalConfigStoreEntry{
-		RowType:   ZonalConfig,
-		Version:   0,
-		Timestamp: time.Time{},
-		Values:    nil,
+	serializer    PayloadSerializer
+	persistence   ConfigStore
+	logger        log.Logger
+	throttleRetry *backoff.ThrottleRetry
+}
+
+// NewGlobalZoneDrains creates a GlobalZoneDrains backed by a ConfigStore row.
+func NewGlobalZoneDrains(serializer PayloadSerializer, store ConfigStore, logger log.Logger) GlobalZoneDrains {
+	return &globalZoneDrainsImpl{
+		serializer:  serializer,
+		persistence: store,
+		logger:      logger,
+		throttleRetry: backoff.NewThrottleRetry(
+			backoff.WithRetryPolicy(configStoreOperationRetryPolicy),
+			backoff.WithRetryableError(IsTransientError),
+		),
+	}
+}
+
+var configStoreOperationRetryPolicy = newConfigStoreOperationRetryPolicy()
+
+func newConfigStoreOperationRetryPolicy() backoff.RetryPolicy {
+	policy := backoff.NewExponentialRetryPolicy(50 * time.Millisecond)
+	policy.SetMaximumInterval(2 * time.Second)
+	policy.SetExpirationInterval(30 * time.Second)
+	return policy
+}
+
+func (z *globalZoneDrainsImpl) GetClusterDrains(ctx context.Context) (map[types.ZoneName]types.ZonePartition, error) {
+	blob, _, err := z.load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cluster zone drains: %w", err)
+	}
+	out := make(map[types.ZoneName]types.ZonePartition, len(blob.Zones))
+	for zone, state := range blob.Zones {
+		out[zone] = state.toZonePartition(zone)
+	}
+	return out, nil
+}
+
+func (z *globalZoneDrainsImpl) PrepareDrain(ctx context.Context, zone types.ZoneName, requiredHosts []string, ttl time.Duration) (int64, error) {
+	var generation int64
+	err := z.mutate(ctx, func(blob *clusterZoneDrainsBlob) error {
+		generation = nextGeneration(blob.Zones[zone])
+		hosts := make(map[string]struct{}, len(requiredHosts))
+		for _, host := range requiredHosts {
+			hosts[host] = struct{}{}
+		}
+		blob.Zones[zone] = &zoneDrainState{
+			Status:        types.ZoneDrainStatusPreparing,
+			Generation:    generation,
+			LeaseExpiry:   time.Now().Add(ttl),
+			RequiredHosts: hosts,
+			Acks:          make(map[string]struct{}),
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare drain for zone %v: %w", zone, err)
+	}
+	z.logger.Info(fmt.Sprintf("prepared zone drain for %v", zone), tag.Number(generation))
+	return generation, nil
+}
+
+func (z *globalZoneDrainsImpl) AckDrain(ctx context.Context, zone types.ZoneName, hostID string, generation int64) error {
+	err := z.mutate(ctx, func(blob *clusterZoneDrainsBlob) error {
+		state, ok := blob.Zones[zone]
+		if !ok || state.Status != types.ZoneDrainStatusPreparing {
+			return ErrDrainNotPreparing
+		}
+		if state.Generation != generation {
+			// Stale ack for a superseded prepare - ignore rather than error so a slow host doesn't
+			// disrupt a newer prepare/commit cycle.
+			z.logger.Info(fmt.Sprintf("ignoring stale drain ack for %v", zone), tag.Number(generation))
+			return nil
+		}
+		state.Acks[hostID] = struct{}{}
+		return nil
 	})
+	if err != nil {
+		return fmt.Errorf("failed to record drain ack for zone %v: %w", zone, err)
+	}
 	return nil
 }
 
-func (z *globalZoneDrainsImpl) GetClusterDrains(ctx context.Context) (map[types.ZoneName]types.ZonePartition, error) {
-	panic("not implemented")
-	z.persistence.FetchConfig(ctx, ZonalConfig)
-	return nil, nil
+func (z *globalZoneDrainsImpl) CommitDrain(ctx context.Context, zone types.ZoneName, generation int64) error {
+	err := z.mutate(ctx, func(blob *clusterZoneDrainsBlob) error {
+		state, ok := blob.Zones[zone]
+		if !ok || state.Status != types.ZoneDrainStatusPreparing {
+			return ErrDrainNotPreparing
+		}
+		if state.Generation != generation {
+			return ErrDrainGenerationMismatch
+		}
+		if time.Now().After(state.LeaseExpiry) {
+			return ErrDrainLeaseExpired
+		}
+		if !state.hasQuorum() {
+			return ErrDrainMissingAcks
+		}
+		state.Status = types.ZoneDrainStatusDrained
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to commit drain for zone %v: %w", zone, err)
+	}
+	z.logger.Info(fmt.Sprintf("committed zone drain for %v", zone), tag.Number(generation))
+	return nil
+}
+
+func (z *globalZoneDrainsImpl) AbortDrain(ctx context.Context, zone types.ZoneName) error {
+	err := z.mutate(ctx, func(blob *clusterZoneDrainsBlob) error {
+		delete(blob.Zones, zone)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to abort drain for zone %v: %w", zone, err)
+	}
+	z.logger.Info(fmt.Sprintf("aborted zone drain for %v", zone))
+	return nil
+}
+
+// load fetches and decodes the current blob, returning a zero-value blob and version 0 if no row has been
+// written yet.
+func (z *globalZoneDrainsImpl) load(ctx context.Context) (*clusterZoneDrainsBlob, int64, error) {
+	entry, err := z.persistence.FetchConfig(ctx, ZonalConfig)
+	if errors.Is(err, ErrConfigStoreEntryNotFound) {
+		return &clusterZoneDrainsBlob{Zones: make(map[types.ZoneName]*zoneDrainState)}, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	blob, err := z.serializer.DeserializeClusterZoneDrains(entry.Values)
+	if err != nil {
+		return nil, 0, err
+	}
+	if blob.Zones == nil {
+		blob.Zones = make(map[types.ZoneName]*zoneDrainState)
+	}
+	return blob, entry.Version, nil
+}
+
+// mutate runs a read-modify-write cycle against the ZonalConfig row with retry/backoff on CAS conflicts,
+// mirroring the throttled-retry pattern taskWriter uses around its own persistence writes. mutateFn errors
+// are not retried - only the CAS race itself is, via IsTransientError.
+func (z *globalZoneDrainsImpl) mutate(ctx context.Context, mutateFn func(*clusterZoneDrainsBlob) error) error {
+	op := func() error {
+		blob, version, err := z.load(ctx)
+		if err != nil {
+			return err
+		}
+		if err := mutateFn(blob); err != nil {
+			return err
+		}
+		payload, err := z.serializer.SerializeClusterZoneDrains(blob)
+		if err != nil {
+			return err
+		}
+		return z.persistence.UpdateConfig(ctx, &InternalConfigStoreEntry{
+			RowType:   ZonalConfig,
+			Version:   version,
+			Timestamp: time.Now(),
+			Values:    payload,
+		})
+	}
+	return z.throttleRetry.Do(ctx, op)
+}
+
+func nextGeneration(existing *zoneDrainState) int64 {
+	if existing == nil {
+		return 1
+	}
+	return existing.Generation + 1
 }
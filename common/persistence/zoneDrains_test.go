@@ -0,0 +1,153 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/types"
+)
+
+// fakeConfigStore is an in-memory ConfigStore backing a single ZonalConfig row, enforcing the same
+// version-mismatch-on-stale-write semantics a real CAS-backed store would.
+type fakeConfigStore struct {
+	mu    sync.Mutex
+	entry *InternalConfigStoreEntry
+}
+
+func (f *fakeConfigStore) FetchConfig(ctx context.Context, rowType ConfigType) (*InternalConfigStoreEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.entry == nil {
+		return nil, ErrConfigStoreEntryNotFound
+	}
+	copied := *f.entry
+	return &copied, nil
+}
+
+func (f *fakeConfigStore) UpdateConfig(ctx context.Context, entry *InternalConfigStoreEntry) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.entry != nil && f.entry.Version != entry.Version {
+		return ErrConfigStoreEntryNotFound
+	}
+	stored := *entry
+	stored.Version = entry.Version + 1
+	f.entry = &stored
+	return nil
+}
+
+// jsonPayloadSerializer is a minimal PayloadSerializer used only by tests.
+type jsonPayloadSerializer struct{}
+
+func (jsonPayloadSerializer) SerializeClusterZoneDrains(blob *clusterZoneDrainsBlob) ([]byte, error) {
+	return json.Marshal(blob)
+}
+
+func (jsonPayloadSerializer) DeserializeClusterZoneDrains(data []byte) (*clusterZoneDrainsBlob, error) {
+	var blob clusterZoneDrainsBlob
+	if err := json.Unmarshal(data, &blob); err != nil {
+		return nil, err
+	}
+	return &blob, nil
+}
+
+func newTestGlobalZoneDrains() GlobalZoneDrains {
+	return NewGlobalZoneDrains(jsonPayloadSerializer{}, &fakeConfigStore{}, log.NewNoop())
+}
+
+func TestGlobalZoneDrains_PrepareAckCommit(t *testing.T) {
+	ctx := context.Background()
+	drains := newTestGlobalZoneDrains()
+
+	generation, err := drains.PrepareDrain(ctx, "z1", []string{"host1", "host2"}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), generation)
+
+	clusterDrains, err := drains.GetClusterDrains(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.ZoneDrainStatusPreparing, clusterDrains["z1"].Status)
+
+	require.NoError(t, drains.AckDrain(ctx, "z1", "host1", generation))
+	assert.ErrorIs(t, drains.CommitDrain(ctx, "z1", generation), ErrDrainMissingAcks)
+
+	require.NoError(t, drains.AckDrain(ctx, "z1", "host2", generation))
+	require.NoError(t, drains.CommitDrain(ctx, "z1", generation))
+
+	clusterDrains, err = drains.GetClusterDrains(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.ZoneDrainStatusDrained, clusterDrains["z1"].Status)
+}
+
+func TestGlobalZoneDrains_AckIgnoredForStaleGeneration(t *testing.T) {
+	ctx := context.Background()
+	drains := newTestGlobalZoneDrains()
+
+	firstGeneration, err := drains.PrepareDrain(ctx, "z1", []string{"host1"}, time.Minute)
+	require.NoError(t, err)
+
+	secondGeneration, err := drains.PrepareDrain(ctx, "z1", []string{"host1"}, time.Minute)
+	require.NoError(t, err)
+	assert.Greater(t, secondGeneration, firstGeneration)
+
+	// Ack against the superseded generation is silently ignored, not an error.
+	require.NoError(t, drains.AckDrain(ctx, "z1", "host1", firstGeneration))
+	assert.ErrorIs(t, drains.CommitDrain(ctx, "z1", secondGeneration), ErrDrainMissingAcks)
+
+	require.NoError(t, drains.AckDrain(ctx, "z1", "host1", secondGeneration))
+	require.NoError(t, drains.CommitDrain(ctx, "z1", secondGeneration))
+}
+
+func TestGlobalZoneDrains_CommitFailsOnGenerationMismatch(t *testing.T) {
+	ctx := context.Background()
+	drains := newTestGlobalZoneDrains()
+
+	generation, err := drains.PrepareDrain(ctx, "z1", nil, time.Minute)
+	require.NoError(t, err)
+
+	err = drains.CommitDrain(ctx, "z1", generation+1)
+	assert.ErrorIs(t, err, ErrDrainGenerationMismatch)
+}
+
+func TestGlobalZoneDrains_CommitFailsWhenNotPreparing(t *testing.T) {
+	ctx := context.Background()
+	drains := newTestGlobalZoneDrains()
+
+	err := drains.CommitDrain(ctx, "z1", 1)
+	assert.ErrorIs(t, err, ErrDrainNotPreparing)
+
+	err = drains.AckDrain(ctx, "z1", "host1", 1)
+	assert.ErrorIs(t, err, ErrDrainNotPreparing)
+}
+
+func TestGlobalZoneDrains_CommitFailsAfterLeaseExpiry(t *testing.T) {
+	ctx := context.Background()
+	drains := newTestGlobalZoneDrains()
+
+	generation, err := drains.PrepareDrain(ctx, "z1", []string{"host1"}, -time.Minute)
+	require.NoError(t, err)
+
+	err = drains.CommitDrain(ctx, "z1", generation)
+	assert.ErrorIs(t, err, ErrDrainLeaseExpired)
+}
+
+func TestGlobalZoneDrains_AbortDrain(t *testing.T) {
+	ctx := context.Background()
+	drains := newTestGlobalZoneDrains()
+
+	_, err := drains.PrepareDrain(ctx, "z1", []string{"host1"}, time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, drains.AbortDrain(ctx, "z1"))
+
+	clusterDrains, err := drains.GetClusterDrains(ctx)
+	require.NoError(t, err)
+	_, exists := clusterDrains["z1"]
+	assert.False(t, exists, "aborted zone should no longer appear in cluster drains")
+}
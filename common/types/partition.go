@@ -3,6 +3,10 @@ package types
 const (
 	ZoneDrainStatusInvalid ZoneStatus = iota
 	ZoneDrainStatusHealthy
+	// ZoneDrainStatusPreparing means a drain has been proposed for the zone but has not yet been committed:
+	// new placements should avoid the zone, but in-flight assignments should be left alone until the drain
+	// commits or aborts.
+	ZoneDrainStatusPreparing
 	ZoneDrainStatusDrained
 )
 
@@ -26,3 +30,30 @@ type ZonePartition struct {
 	Name   ZoneName
 	Status ZoneStatus
 }
+
+// ZoneAffinity is a single scoring rule contributing to zone selection: when a workflow's PartitionConfig
+// has `Attribute` set to `Value`, `Weight` is added to the score of every candidate zone named `Zone`.
+// Weight may be negative to express an anti-affinity.
+type ZoneAffinity struct {
+	Zone      ZoneName `json:"zone"`
+	Attribute string   `json:"attribute"`
+	Value     string   `json:"value"`
+	Weight    int32    `json:"weight"`
+}
+
+// ZoneSpread is the desired percentage of placements that should land in Zone, out of the zones
+// currently healthy. Percent values across a ZonePlacementPolicy's Spreads need not sum to 100; zones with
+// no entry are treated as having a target of 0.
+type ZoneSpread struct {
+	Zone    ZoneName `json:"zone"`
+	Percent float64  `json:"percent"`
+}
+
+// ZonePlacementPolicy is the per-domain operator configuration for zone selection. It is persisted in its
+// own ConfigStore row (see persistence.DomainConfigStore), keyed by domain name, and is not stored on the
+// domain record alongside ZoneConfig. It is consulted whenever a partitioner has to pick a zone among several
+// healthy candidates, e.g. after the workflow's preferred zone has been drained.
+type ZonePlacementPolicy struct {
+	Affinities []ZoneAffinity `json:"affinities,omitempty"`
+	Spreads    []ZoneSpread   `json:"spreads,omitempty"`
+}
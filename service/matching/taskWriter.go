@@ -23,15 +23,19 @@ package matching
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/partition"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
 )
@@ -69,12 +73,26 @@ type (
 		stopCh         chan struct{} // shutdown signal for all routines in this class
 		throttleRetry  *backoff.ThrottleRetry
 		handleErr      func(error) error
+		partitioner    partition.Partitioner
+		localZone      types.ZoneName
+		drainCache     *drainDecisionCache
 	}
 )
 
 // errShutdown indicates that the task list is shutting down
 var errShutdown = errors.New("task list shutting down")
 
+// errZoneDrained indicates that the local zone is drained for this taskList and the task should instead
+// be routed to Zone. checkZoneDrain returns it only when zone forwarding is enabled, and AddTask then
+// forwards the task to Zone; with forwarding disabled a service-busy error is returned instead.
+type errZoneDrained struct {
+	Zone types.ZoneName
+}
+
+func (e *errZoneDrained) Error() string {
+	return fmt.Sprintf("taskList's zone is drained, tasks should be routed to zone %q", e.Zone)
+}
+
 func newTaskWriter(tlMgr *taskListManagerImpl) *taskWriter {
 	return &taskWriter{
 		tlMgr:          tlMgr,
@@ -87,6 +105,9 @@ func newTaskWriter(tlMgr *taskListManagerImpl) *taskWriter {
 		logger:         tlMgr.logger,
 		scope:          tlMgr.scope,
 		handleErr:      tlMgr.handleErr,
+		partitioner:    tlMgr.partitioner,
+		localZone:      tlMgr.config.LocalZone,
+		drainCache:     newDrainDecisionCache(tlMgr.config.ZoneDrainCacheTTL()),
 		throttleRetry: backoff.NewThrottleRetry(
 			backoff.WithRetryPolicy(persistenceOperationRetryPolicy),
 			backoff.WithRetryableError(persistence.IsTransientError),
@@ -127,6 +148,10 @@ func (w *taskWriter) appendTask(execution *types.WorkflowExecution,
 		return nil, errShutdown
 	}
 
+	if err := w.checkZoneDrain(execution, taskInfo); err != nil {
+		return nil, err
+	}
+
 	ch := make(chan *writeTaskResponse)
 	req := &writeTaskRequest{
 		execution:  execution,
@@ -278,3 +303,107 @@ func (w *taskWriter) sendWriteResponse(reqs []*writeTaskRequest,
 		req.responseCh <- resp
 	}
 }
+
+// startZonePartitionKey mirrors the json tag partition.DefaultPartitionConfig uses for the workflow's
+// start zone, so taskInfo.PartitionConfig (populated from the same source at start-workflow time) can be
+// read back without re-deriving it.
+const startZonePartitionKey = "wf-start-zone"
+
+// checkZoneDrain rejects or redirects appendTask calls while the local zone is drained. It is a no-op
+// when the taskList has no partitioner wired up (zonal partitioning disabled) or no local zone configured.
+func (w *taskWriter) checkZoneDrain(execution *types.WorkflowExecution, taskInfo *persistence.TaskInfo) error {
+	if w.partitioner == nil || w.localZone == "" {
+		return nil
+	}
+
+	now := time.Now()
+	drained, ok := w.drainCache.get(now)
+	if !ok {
+		var err error
+		// Appends are for work already pinned to this zone, so a merely Preparing drain shouldn't
+		// reject/forward them - only a committed Drained state should. IsDrainedByDomainID would treat
+		// Preparing the same as Drained, which is correct for *new* placements but would needlessly
+		// disrupt in-flight work the moment a drain is proposed, defeating the two-phase protocol.
+		drained, err = w.partitioner.IsFullyDrainedByDomainID(context.Background(), w.taskListID.domainID, w.localZone)
+		if err != nil {
+			return fmt.Errorf("failed to check zone drain state: %w", err)
+		}
+		w.drainCache.set(drained, now)
+	}
+	if !drained {
+		return nil
+	}
+
+	partitionConfig, err := partitionConfigForTask(execution, taskInfo)
+	if err != nil {
+		return fmt.Errorf("failed to derive partition config for task: %w", err)
+	}
+	targetZone, err := w.partitioner.GetTaskZone(context.Background(), w.taskListID.domainID, partitionConfig)
+	if err != nil {
+		return fmt.Errorf("failed to resolve target zone for drained zone %q: %w", w.localZone, err)
+	}
+
+	if !w.config.EnableZoneForwarding() {
+		w.scope.IncCounter(metrics.TasksRejectedDrainedZoneCounter)
+		return createServiceBusyError("TaskList's zone is drained and forwarding to other zones is disabled")
+	}
+
+	w.scope.IncCounter(metrics.TasksForwardedDrainedZoneCounter)
+	return &errZoneDrained{Zone: *targetZone}
+}
+
+// invalidateZoneDrainCache forces the next appendTask to re-check zone drain state rather than relying on
+// the cached decision. Nothing in this package calls it: no drain state-change notification is wired to
+// it, so a cached decision otherwise stands until the cache's TTL runs out.
+func (w *taskWriter) invalidateZoneDrainCache() {
+	w.drainCache.invalidate()
+}
+
+// partitionConfigForTask builds the types.PartitionConfig a Partitioner needs to resolve a target zone:
+// the workflow's RunID (for deterministic placement) and its start zone, lifted out of taskInfo's own
+// partition config so it doesn't need to be re-derived from domain/workflow state on every append.
+func partitionConfigForTask(execution *types.WorkflowExecution, taskInfo *persistence.TaskInfo) (types.PartitionConfig, error) {
+	cfg := partition.DefaultPartitionConfig{
+		RunID:             execution.GetRunID(),
+		WorkflowStartZone: types.ZoneName(taskInfo.PartitionConfig[startZonePartitionKey]),
+		Attributes:        taskInfo.PartitionConfig,
+	}
+	return json.Marshal(cfg)
+}
+
+// drainDecisionCache remembers, for a short TTL, whether the local zone was drained the last time it was
+// checked - so a hot taskList doesn't call GlobalZoneDrains.GetClusterDrains on every single append.
+type drainDecisionCache struct {
+	mu        sync.RWMutex
+	ttl       time.Duration
+	checkedAt time.Time
+	drained   bool
+	valid     bool
+}
+
+func newDrainDecisionCache(ttl time.Duration) *drainDecisionCache {
+	return &drainDecisionCache{ttl: ttl}
+}
+
+func (c *drainDecisionCache) get(now time.Time) (drained bool, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.valid || now.Sub(c.checkedAt) > c.ttl {
+		return false, false
+	}
+	return c.drained, true
+}
+
+func (c *drainDecisionCache) set(drained bool, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.drained = drained
+	c.checkedAt = now
+	c.valid = true
+}
+
+func (c *drainDecisionCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.valid = false
+}
@@ -0,0 +1,122 @@
+package matching
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+// fakePartitioner is a minimal partition.Partitioner for exercising checkZoneDrain without wiring up a
+// real drain/domain backend.
+type fakePartitioner struct {
+	drained      bool
+	fullyDrained bool
+	drainErr     error
+	targetZone   types.ZoneName
+	zoneErr      error
+}
+
+func (f *fakePartitioner) IsDrained(ctx context.Context, domain string, zone types.ZoneName) (bool, error) {
+	return f.drained, f.drainErr
+}
+
+func (f *fakePartitioner) IsDrainedByDomainID(ctx context.Context, domainID string, zone types.ZoneName) (bool, error) {
+	return f.drained, f.drainErr
+}
+
+func (f *fakePartitioner) IsFullyDrainedByDomainID(ctx context.Context, domainID string, zone types.ZoneName) (bool, error) {
+	return f.fullyDrained, f.drainErr
+}
+
+func (f *fakePartitioner) GetTaskZone(ctx context.Context, domainID string, key types.PartitionConfig) (*types.ZoneName, error) {
+	if f.zoneErr != nil {
+		return nil, f.zoneErr
+	}
+	zone := f.targetZone
+	return &zone, nil
+}
+
+func (f *fakePartitioner) ValidateStartWorkflowPartitionConfig(domain string, key types.PartitionConfig) error {
+	return nil
+}
+
+func newTestTaskWriter(partitioner *fakePartitioner, localZone types.ZoneName, forwardingEnabled bool) *taskWriter {
+	return &taskWriter{
+		taskListID: &taskListID{domainID: "test-domain-id"},
+		config: &taskListConfig{
+			EnableZoneForwarding: func() bool { return forwardingEnabled },
+		},
+		scope:       metrics.NoopScope(metrics.Matching),
+		partitioner: partitioner,
+		localZone:   localZone,
+		drainCache:  newDrainDecisionCache(time.Minute),
+	}
+}
+
+func TestCheckZoneDrain_NotDrained(t *testing.T) {
+	w := newTestTaskWriter(&fakePartitioner{fullyDrained: false}, "z1", true)
+	err := w.checkZoneDrain(&types.WorkflowExecution{RunID: "run-1"}, &persistence.TaskInfo{})
+	assert.NoError(t, err)
+}
+
+func TestCheckZoneDrain_DrainedAndForwardingDisabled_Rejects(t *testing.T) {
+	w := newTestTaskWriter(&fakePartitioner{fullyDrained: true, targetZone: "z2"}, "z1", false)
+	err := w.checkZoneDrain(&types.WorkflowExecution{RunID: "run-1"}, &persistence.TaskInfo{})
+	require.Error(t, err)
+	var zoneDrained *errZoneDrained
+	assert.False(t, errors.As(err, &zoneDrained), "should reject, not return a forwardable errZoneDrained")
+}
+
+func TestCheckZoneDrain_DrainedAndForwardingEnabled_ReturnsErrZoneDrained(t *testing.T) {
+	w := newTestTaskWriter(&fakePartitioner{fullyDrained: true, targetZone: "z2"}, "z1", true)
+	err := w.checkZoneDrain(&types.WorkflowExecution{RunID: "run-1"}, &persistence.TaskInfo{})
+	require.Error(t, err)
+	var zoneDrained *errZoneDrained
+	require.True(t, errors.As(err, &zoneDrained))
+	assert.Equal(t, types.ZoneName("z2"), zoneDrained.Zone)
+}
+
+// TestCheckZoneDrain_PreparingDoesNotRejectInFlightAppends proves checkZoneDrain only rejects/forwards
+// once a drain has actually committed - a zone that is merely Preparing (modeled here by a partitioner
+// whose IsFullyDrainedByDomainID has not yet flipped) keeps accepting appends for work already pinned to
+// it, so the two-phase drain protocol doesn't disrupt in-flight executions the moment a drain is proposed.
+func TestCheckZoneDrain_PreparingDoesNotRejectInFlightAppends(t *testing.T) {
+	w := newTestTaskWriter(&fakePartitioner{fullyDrained: false, targetZone: "z2"}, "z1", true)
+	err := w.checkZoneDrain(&types.WorkflowExecution{RunID: "run-1"}, &persistence.TaskInfo{})
+	assert.NoError(t, err)
+}
+
+func TestCheckZoneDrain_NoopWithoutPartitionerOrLocalZone(t *testing.T) {
+	w := newTestTaskWriter(nil, "", true)
+	err := w.checkZoneDrain(&types.WorkflowExecution{RunID: "run-1"}, &persistence.TaskInfo{})
+	assert.NoError(t, err)
+}
+
+func TestDrainDecisionCache(t *testing.T) {
+	cache := newDrainDecisionCache(time.Minute)
+	now := time.Now()
+
+	_, ok := cache.get(now)
+	assert.False(t, ok, "unset cache should miss")
+
+	cache.set(true, now)
+	drained, ok := cache.get(now)
+	require.True(t, ok)
+	assert.True(t, drained)
+
+	_, ok = cache.get(now.Add(2 * time.Minute))
+	assert.False(t, ok, "entry past its TTL should miss")
+
+	cache.set(false, now)
+	cache.invalidate()
+	_, ok = cache.get(now)
+	assert.False(t, ok, "invalidated entry should miss even within TTL")
+}
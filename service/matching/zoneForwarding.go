@@ -0,0 +1,57 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+// Portions of the Software are attributed to Copyright (c) 2020 Temporal Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"context"
+	"errors"
+
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+// errNoZoneForwarder is returned by AddTask when EnableZoneForwarding is true but no zoneForwarder has
+// been wired up to actually carry out the forward.
+var errNoZoneForwarder = errors.New("zone forwarding is enabled but no forwarder is configured")
+
+// zoneForwarder sends an AddTask request on to the matching host responsible for zone, so a client whose
+// request landed on a task list in a drained zone doesn't have to discover and retry against the right
+// zone itself. This, along with the partitioner/forwarder/writer fields it's used through on
+// taskListManagerImpl, is the zone-aware addition this backlog makes to the existing task list manager -
+// taskListManagerImpl/taskListConfig/taskListDB/taskListID themselves are not redeclared here.
+type zoneForwarder interface {
+	ForwardTask(ctx context.Context, zone types.ZoneName, execution *types.WorkflowExecution, taskInfo *persistence.TaskInfo) (*persistence.CreateTasksResponse, error)
+}
+
+// AddTask appends a task for execution to the task list, forwarding it to another zone instead when the
+// local zone is drained and forwarding is enabled.
+func (tlMgr *taskListManagerImpl) AddTask(ctx context.Context, execution *types.WorkflowExecution, taskInfo *persistence.TaskInfo) (*persistence.CreateTasksResponse, error) {
+	resp, err := tlMgr.writer.appendTask(execution, taskInfo)
+	var drained *errZoneDrained
+	if !errors.As(err, &drained) {
+		return resp, err
+	}
+	if tlMgr.forwarder == nil {
+		return nil, errNoZoneForwarder
+	}
+	return tlMgr.forwarder.ForwardTask(ctx, drained.Zone, execution, taskInfo)
+}